@@ -1,107 +1,416 @@
 package main
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
 	"image/gif"
 	"image/jpeg"
 	"image/png"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+	"github.com/chai2010/webp"
 	"github.com/disintegration/imaging"
-	"golang.org/x/image/webp"
+	"github.com/gen2brain/avif"
+	"github.com/zachbharris/emote-resize/thumbnailer"
+	xwebp "golang.org/x/image/webp"
 )
 
+// OutputFormat is the file format an EmoteSize is encoded to.
+type OutputFormat string
+
+const (
+	FormatPNG  OutputFormat = "png"
+	FormatGIF  OutputFormat = "gif"
+	FormatWebP OutputFormat = "webp"
+	FormatAVIF OutputFormat = "avif"
+)
+
+// minQuality and maxQuality bound the binary search used to hit a
+// MaxBytes budget on lossy encoders (WebP, AVIF).
+const (
+	minQuality = 30
+	maxQuality = 95
+)
+
+// ResizeStrategy selects how an image is fit into an EmoteSize's exact
+// dimensions.
+type ResizeStrategy string
+
+const (
+	StrategyFill           ResizeStrategy = "fill"            // center-crop to fill (default)
+	StrategyFit            ResizeStrategy = "fit"             // scale to fit within bounds, no crop
+	StrategyPadTransparent ResizeStrategy = "pad_transparent" // fit, then pad onto a transparent canvas
+	StrategySmartCrop      ResizeStrategy = "smart_crop"      // crop to the highest-energy region, then fill
+)
+
+// ResampleFilter selects the interpolation kernel used when resizing.
+type ResampleFilter string
+
+const (
+	FilterLanczos    ResampleFilter = "lanczos"
+	FilterCatmullRom ResampleFilter = "catmull_rom"
+	FilterLinear     ResampleFilter = "linear"
+	FilterNearest    ResampleFilter = "nearest_neighbor"
+)
+
+// imaging returns the disintegration/imaging filter this ResampleFilter
+// corresponds to, defaulting to Lanczos for an unset/unknown value.
+func (f ResampleFilter) imaging() imaging.ResampleFilter {
+	switch f {
+	case FilterCatmullRom:
+		return imaging.CatmullRom
+	case FilterLinear:
+		return imaging.Linear
+	case FilterNearest:
+		return imaging.NearestNeighbor
+	default:
+		return imaging.Lanczos
+	}
+}
+
 // EmoteSize represents a target emote size with platform and dimensions
 type EmoteSize struct {
-	Platform string
-	Name     string
-	Width    int
-	Height   int
+	Platform     string
+	Name         string
+	Width        int
+	Height       int
+	Animated     bool
+	OutputFormat OutputFormat
+	MaxBytes     int            // 0 means no byte budget is enforced
+	Strategy     ResizeStrategy // "" means use the bundle's default strategy
 }
 
 // Define all emote sizes
 var emoteSizes = []EmoteSize{
 	// Discord emote sizes
-	{"Discord", "Small", 28, 28},
-	{"Discord", "Medium", 32, 32},
-	{"Discord", "Large", 48, 48},
-	{"Discord", "Animated", 128, 128},
+	{Platform: "Discord", Name: "Small", Width: 28, Height: 28, OutputFormat: FormatPNG},
+	{Platform: "Discord", Name: "Medium", Width: 32, Height: 32, OutputFormat: FormatPNG},
+	{Platform: "Discord", Name: "Large", Width: 48, Height: 48, OutputFormat: FormatPNG},
+	{Platform: "Discord", Name: "Animated", Width: 128, Height: 128, Animated: true, OutputFormat: FormatGIF, MaxBytes: 256 * 1024},
 
 	// Twitch emote sizes
-	{"Twitch", "1.0", 28, 28},
-	{"Twitch", "2.0", 56, 56},
-	{"Twitch", "3.0", 112, 112},
+	{Platform: "Twitch", Name: "1.0", Width: 28, Height: 28, OutputFormat: FormatPNG},
+	{Platform: "Twitch", Name: "2.0", Width: 56, Height: 56, OutputFormat: FormatPNG},
+	{Platform: "Twitch", Name: "3.0", Width: 112, Height: 112, OutputFormat: FormatPNG},
 
 	// 7TV emote sizes
-	{"7TV", "1x", 32, 32},
-	{"7TV", "2x", 64, 64},
-	{"7TV", "3x", 96, 96},
-	{"7TV", "4x", 128, 128},
+	{Platform: "7TV", Name: "1x", Width: 32, Height: 32, OutputFormat: FormatPNG},
+	{Platform: "7TV", Name: "2x", Width: 64, Height: 64, OutputFormat: FormatPNG},
+	{Platform: "7TV", Name: "3x", Width: 96, Height: 96, OutputFormat: FormatPNG},
+	{Platform: "7TV", Name: "4x", Width: 128, Height: 128, Animated: true, OutputFormat: FormatGIF, MaxBytes: 256 * 1024},
+}
+
+// emoteSizeKey identifies an EmoteSize for storage in a Preset,
+// independent of its position in emoteSizes.
+func emoteSizeKey(s EmoteSize) string {
+	return s.Platform + "/" + s.Name
+}
+
+// prefsKeyPresets is the fyne.Preferences key the user's Preset list is
+// persisted under, as a JSON blob.
+const prefsKeyPresets = "presets"
+
+// Preset is a named subset of emoteSizes, persisted to
+// fyne.Preferences so a streamer can re-run the same subset of
+// platforms/sizes across many uploads without reselecting them. The UI
+// only picks among the stored presets; it has no control for creating,
+// renaming or changing one.
+type Preset struct {
+	Name string
+	Keys []string
+}
+
+// Sizes resolves a Preset's Keys back to the matching EmoteSize
+// entries, in emoteSizes order.
+func (p Preset) Sizes() []EmoteSize {
+	wanted := make(map[string]bool, len(p.Keys))
+	for _, k := range p.Keys {
+		wanted[k] = true
+	}
+
+	sizes := make([]EmoteSize, 0, len(p.Keys))
+	for _, s := range emoteSizes {
+		if wanted[emoteSizeKey(s)] {
+			sizes = append(sizes, s)
+		}
+	}
+	return sizes
+}
+
+// defaultPresets seeds the preset list the first time the app runs, or
+// whenever stored preferences can't be parsed.
+func defaultPresets() []Preset {
+	var all, discordOnly, sevenTVAnimated []string
+	for _, s := range emoteSizes {
+		key := emoteSizeKey(s)
+		all = append(all, key)
+		if s.Platform == "Discord" {
+			discordOnly = append(discordOnly, key)
+		}
+		if s.Platform == "7TV" && s.Animated {
+			sevenTVAnimated = append(sevenTVAnimated, key)
+		}
+	}
+
+	return []Preset{
+		{Name: "All Sizes", Keys: all},
+		{Name: "Discord only", Keys: discordOnly},
+		{Name: "7TV animated only", Keys: sevenTVAnimated},
+	}
+}
+
+// loadPresets reads the persisted preset list from prefs, seeding (and
+// saving) defaultPresets the first time the app runs or if the stored
+// value fails to parse.
+func loadPresets(prefs fyne.Preferences) []Preset {
+	raw := prefs.String(prefsKeyPresets)
+	if raw != "" {
+		var presets []Preset
+		if err := json.Unmarshal([]byte(raw), &presets); err == nil && len(presets) > 0 {
+			return presets
+		}
+	}
+
+	presets := defaultPresets()
+	savePresets(prefs, presets)
+	return presets
+}
+
+// savePresets persists presets to prefs as a JSON blob.
+func savePresets(prefs fyne.Preferences, presets []Preset) {
+	data, err := json.Marshal(presets)
+	if err != nil {
+		return
+	}
+	prefs.SetString(prefsKeyPresets, string(data))
 }
 
 type App struct {
-	window       fyne.Window
-	selectedFile string
-	statusLabel  *widget.Label
-	convertBtn   *widget.Button
-	previewCard  *widget.Card
-	previewImage *widget.Icon
+	window         fyne.Window
+	prefs          fyne.Preferences
+	selectedFile   string
+	selectedFiles  []string
+	outputFormat   OutputFormat
+	strategy       ResizeStrategy
+	resampleFilter ResampleFilter
+	presets        []Preset
+	selectedPreset Preset
+	zipBundle      bool
+	statusLabel    *widget.Label
+	convertBtn     *widget.Button
+	addFileBtn     *widget.Button
+	addFolderBtn   *widget.Button
+	clearBtn       *widget.Button
+	previewCard    *widget.Card
+	previewImage   *widget.Icon
+	formatSelect   *widget.Select
+	strategySelect *widget.Select
+	filterSelect   *widget.Select
+	presetSelect   *widget.Select
+	zipCheck       *widget.Check
+	progressBar    *widget.ProgressBar
+	statusItems    binding.StringList
+	statusList     *widget.List
+	fileItems      binding.StringList
+	fileList       *widget.List
+}
+
+// strategyLabels and filterLabels map the enums' stable on-disk values
+// to the labels shown in the UI dropdowns, in display order.
+var strategyLabels = []struct {
+	Label    string
+	Strategy ResizeStrategy
+}{
+	{"Fill (crop to fill)", StrategyFill},
+	{"Fit (no crop)", StrategyFit},
+	{"Pad (transparent)", StrategyPadTransparent},
+	{"Smart Crop", StrategySmartCrop},
+}
+
+var filterLabels = []struct {
+	Label  string
+	Filter ResampleFilter
+}{
+	{"Lanczos", FilterLanczos},
+	{"Catmull-Rom", FilterCatmullRom},
+	{"Linear", FilterLinear},
+	{"Nearest Neighbor", FilterNearest},
 }
 
 func main() {
 	myApp := app.NewWithID("com.emoteconverter.app")
 	myApp.SetIcon(nil)
-	
+
 	w := myApp.NewWindow("Emote Size Converter")
-	w.Resize(fyne.NewSize(500, 300))
+	w.Resize(fyne.NewSize(500, 450))
 	w.CenterOnScreen()
 
+	presets := loadPresets(myApp.Preferences())
+
 	converter := &App{
-		window:       w,
-		statusLabel:  widget.NewLabel("No file selected"),
-		convertBtn:   widget.NewButton("Convert & Save", nil),
-		previewImage: widget.NewIcon(nil),
+		window:         w,
+		prefs:          myApp.Preferences(),
+		outputFormat:   FormatPNG,
+		strategy:       StrategyFill,
+		resampleFilter: FilterLanczos,
+		presets:        presets,
+		selectedPreset: presets[0],
+		statusLabel:    widget.NewLabel("No file selected"),
+		convertBtn:     widget.NewButton("Convert & Save", nil),
+		previewImage:   widget.NewIcon(nil),
+		progressBar:    widget.NewProgressBar(),
+		statusItems:    binding.NewStringList(),
+		fileItems:      binding.NewStringList(),
 	}
 
 	converter.previewCard = widget.NewCard("Preview", "", converter.previewImage)
 
 	converter.convertBtn.Disable()
 	converter.setupUI()
-	
+	w.SetOnDropped(converter.handleDropped)
+
 	w.ShowAndRun()
 }
 
 func (a *App) setupUI() {
-	title := widget.NewCard("Emote Converter", "", 
+	title := widget.NewCard("Emote Converter", "",
 		widget.NewLabel("Convert images to Discord, Twitch, and 7TV emote sizes"))
 
-	selectBtn := widget.NewButton("Select Image File", a.selectFile)
-	selectBtn.Importance = widget.MediumImportance
+	a.addFileBtn = widget.NewButton("Add Image File(s)", a.selectFile)
+	a.addFileBtn.Importance = widget.MediumImportance
+
+	a.addFolderBtn = widget.NewButton("Add Folder", a.selectFolder)
+	a.addFolderBtn.Importance = widget.MediumImportance
+
+	a.clearBtn = widget.NewButton("Clear", a.clearFiles)
+
+	presetChoices := make([]string, len(a.presets))
+	for i, p := range a.presets {
+		presetChoices[i] = p.Name
+	}
+	a.presetSelect = widget.NewSelect(presetChoices, func(choice string) {
+		for _, p := range a.presets {
+			if p.Name == choice {
+				a.selectedPreset = p
+				return
+			}
+		}
+	})
+	a.presetSelect.SetSelected(a.selectedPreset.Name)
+
+	a.zipCheck = widget.NewCheck("Zip bundle", func(checked bool) {
+		a.zipBundle = checked
+	})
+
+	a.formatSelect = widget.NewSelect([]string{"PNG", "WebP", "AVIF"}, func(choice string) {
+		a.outputFormat = OutputFormat(strings.ToLower(choice))
+	})
+	a.formatSelect.SetSelected("PNG")
+
+	strategyChoices := make([]string, len(strategyLabels))
+	for i, s := range strategyLabels {
+		strategyChoices[i] = s.Label
+	}
+	a.strategySelect = widget.NewSelect(strategyChoices, func(choice string) {
+		for _, s := range strategyLabels {
+			if s.Label == choice {
+				a.strategy = s.Strategy
+				return
+			}
+		}
+	})
+	a.strategySelect.SetSelected(strategyLabels[0].Label)
+
+	filterChoices := make([]string, len(filterLabels))
+	for i, f := range filterLabels {
+		filterChoices[i] = f.Label
+	}
+	a.filterSelect = widget.NewSelect(filterChoices, func(choice string) {
+		for _, f := range filterLabels {
+			if f.Label == choice {
+				a.resampleFilter = f.Filter
+				return
+			}
+		}
+	})
+	a.filterSelect.SetSelected(filterLabels[0].Label)
 
 	a.convertBtn.OnTapped = a.convertAndSave
 	a.convertBtn.Importance = widget.HighImportance
 
-	buttonContainer := container.NewHBox(selectBtn, a.convertBtn)
-	
+	buttonContainer := container.NewHBox(a.addFileBtn, a.addFolderBtn, a.clearBtn, a.convertBtn)
+
+	a.fileList = widget.NewListWithData(a.fileItems,
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(item binding.DataItem, obj fyne.CanvasObject) {
+			str, _ := item.(binding.String).Get()
+			obj.(*widget.Label).SetText(str)
+		},
+	)
+	fileScroll := container.NewVScroll(a.fileList)
+	fileScroll.SetMinSize(fyne.NewSize(0, 80))
+
+	a.statusList = widget.NewListWithData(a.statusItems,
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(item binding.DataItem, obj fyne.CanvasObject) {
+			str, _ := item.(binding.String).Get()
+			obj.(*widget.Label).SetText(str)
+		},
+	)
+	statusScroll := container.NewVScroll(a.statusList)
+	statusScroll.SetMinSize(fyne.NewSize(0, 120))
+
 	content := container.NewVBox(
 		title,
 		a.previewCard, // Add preview card
 		widget.NewSeparator(),
+		fileScroll,
+		container.NewHBox(widget.NewLabel("Preset:"), a.presetSelect),
+		container.NewHBox(widget.NewLabel("Output format (static sizes only):"), a.formatSelect),
+		container.NewHBox(widget.NewLabel("Resize strategy:"), a.strategySelect),
+		container.NewHBox(widget.NewLabel("Resample filter:"), a.filterSelect),
+		a.zipCheck,
 		buttonContainer,
 		a.statusLabel,
+		a.progressBar,
+		statusScroll,
 	)
 
 	a.window.SetContent(container.NewPadded(content))
 }
 
+// validImageExts are the file extensions accepted anywhere a source
+// image is picked: single file, folder scan, or drag-and-drop.
+var validImageExts = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+	".avif": true,
+}
+
 func (a *App) selectFile() {
 	dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
 		if err != nil {
@@ -113,115 +422,718 @@ func (a *App) selectFile() {
 		}
 		defer reader.Close()
 
-		// Check file extension
-		uri := reader.URI()
-		ext := strings.ToLower(filepath.Ext(uri.Path()))
-		
-		validExts := map[string]bool{
-			".jpg":  true,
-			".jpeg": true,
-			".png":  true,
-			".gif":  true,
-		}
+		a.addFile(reader.URI().Path())
+	}, a.window)
+}
 
-		if !validExts[ext] {
-			a.showError("Invalid file type", fmt.Errorf("please select a JPEG, PNG, or GIF file"))
+func (a *App) selectFolder() {
+	dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+		if err != nil {
+			a.showError("Error opening folder", err)
+			return
+		}
+		if uri == nil {
 			return
 		}
 
-		a.selectedFile = uri.Path()
-		filename := filepath.Base(a.selectedFile)
-		a.statusLabel.SetText(fmt.Sprintf("Selected: %s", filename))
-		
-		// Load and show preview
-		a.loadPreview()
-		
-		a.convertBtn.Enable()
+		entries, err := uri.List()
+		if err != nil {
+			a.showError("Error reading folder", err)
+			return
+		}
 
+		added := 0
+		for _, entry := range entries {
+			if validImageExts[strings.ToLower(filepath.Ext(entry.Path()))] {
+				a.addFile(entry.Path())
+				added++
+			}
+		}
+		if added == 0 {
+			a.statusLabel.SetText("No image files found in that folder")
+		}
 	}, a.window)
 }
 
+// handleDropped is wired up via window.SetOnDropped so users can drag
+// a batch of images straight onto the window instead of picking them
+// one at a time.
+func (a *App) handleDropped(_ fyne.Position, items []fyne.URI) {
+	added := 0
+	for _, item := range items {
+		if validImageExts[strings.ToLower(filepath.Ext(item.Path()))] {
+			a.addFile(item.Path())
+			added++
+		}
+	}
+	if added == 0 {
+		a.statusLabel.SetText("No supported image files were dropped")
+	}
+}
+
+// addFile validates ext, appends path to the batch (skipping
+// duplicates), and refreshes the file list and preview.
+func (a *App) addFile(path string) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !validImageExts[ext] {
+		a.showError("Invalid file type", fmt.Errorf("%s is not a JPEG, PNG, GIF, WebP, or AVIF file", filepath.Base(path)))
+		return
+	}
+
+	for _, existing := range a.selectedFiles {
+		if existing == path {
+			return
+		}
+	}
+
+	a.selectedFiles = append(a.selectedFiles, path)
+	a.fileItems.Append(filepath.Base(path))
+
+	a.selectedFile = path
+	a.statusLabel.SetText(fmt.Sprintf("%d file(s) selected", len(a.selectedFiles)))
+	a.loadPreview()
+	a.convertBtn.Enable()
+}
+
+// clearFiles empties the current batch selection.
+func (a *App) clearFiles() {
+	a.selectedFiles = nil
+	a.selectedFile = ""
+	a.fileItems.Set(nil)
+	a.statusLabel.SetText("No file selected")
+	a.convertBtn.Disable()
+	a.previewCard.Hide()
+}
+
 func (a *App) convertAndSave() {
-	if a.selectedFile == "" {
+	if len(a.selectedFiles) == 0 {
 		return
 	}
 
 	a.convertBtn.Disable()
+	a.addFileBtn.Disable()
+	a.addFolderBtn.Disable()
+	a.clearBtn.Disable()
 	a.statusLabel.SetText("Converting images...")
+	a.statusItems.Set(nil)
+	a.progressBar.SetValue(0)
 
 	go func() {
-		err := a.processImage()
-		if err != nil {
-			a.showError("Conversion failed", err)
-			a.convertBtn.Enable()
+		sizes := a.selectedPreset.Sizes()
+		var bundleDirs []string
+		totalFailures := 0
+
+		for _, file := range a.selectedFiles {
+			failures, bundleDir, err := a.processFile(file, sizes)
+			if err != nil {
+				a.showError(fmt.Sprintf("Conversion failed for %s", filepath.Base(file)), err)
+				totalFailures++
+				continue
+			}
+			totalFailures += failures
+			bundleDirs = append(bundleDirs, bundleDir)
+		}
+
+		if a.zipBundle && len(bundleDirs) > 0 {
+			zipPath := filepath.Join(filepath.Dir(bundleDirs[0]), "emotes.zip")
+			if err := zipBundles(bundleDirs, zipPath); err != nil {
+				a.showError("Failed to zip bundle", err)
+			}
+		}
+
+		a.convertBtn.Enable()
+		a.addFileBtn.Enable()
+		a.addFolderBtn.Enable()
+		a.clearBtn.Enable()
+
+		if totalFailures > 0 {
+			a.statusLabel.SetText(fmt.Sprintf("Conversion completed with %d error(s)", totalFailures))
 			return
 		}
 
 		a.statusLabel.SetText("Conversion completed successfully!")
-		a.convertBtn.Enable()
-		
+
 		// Show success dialog
-		dialog.ShowInformation("Success", 
+		dialog.ShowInformation("Success",
 			"All emote sizes have been created and saved!", a.window)
 	}()
 }
 
-func (a *App) processImage() error {
-	// Open and decode the image
-	file, err := os.Open(a.selectedFile)
+// processFile decodes selectedFile, fans a resize/encode job per size
+// out across a thumbnailer.Pool, and streams progress into the status
+// list and progress bar as jobs complete. It returns the number of
+// jobs that failed and the bundle directory written to, alongside a
+// fatal error for anything that prevents the bundle from starting at
+// all (open/decode/mkdir).
+func (a *App) processFile(selectedFile string, sizes []EmoteSize) (int, string, error) {
+	ext := strings.ToLower(filepath.Ext(selectedFile))
+	isGIF := ext == ".gif"
+
+	// Open and decode the image. Animated GIFs are decoded as a full
+	// frame sequence so the animation survives resizing; everything
+	// else decodes to a single static image.
+	var img image.Image
+	var anim *gif.GIF
+
+	file, err := os.Open(selectedFile)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return 0, "", fmt.Errorf("failed to open file: %w", err)
 	}
-	defer file.Close()
 
-	// Decode based on file extension
-	var img image.Image
-	ext := strings.ToLower(filepath.Ext(a.selectedFile))
-	
-	switch ext {
-	case ".jpg", ".jpeg":
-		img, err = jpeg.Decode(file)
-	case ".png":
-		img, err = png.Decode(file)
-	case ".gif":
-		img, err = gif.Decode(file)
-	default:
-		img, _, err = image.Decode(file)
+	if isGIF {
+		anim, err = gif.DecodeAll(file)
+	} else {
+		switch ext {
+		case ".jpg", ".jpeg":
+			img, err = jpeg.Decode(file)
+		case ".png":
+			img, err = png.Decode(file)
+		case ".webp":
+			img, err = xwebp.Decode(file)
+		case ".avif":
+			img, err = avif.Decode(file)
+		default:
+			img, _, err = image.Decode(file)
+		}
 	}
+	file.Close()
 
 	if err != nil {
-		return fmt.Errorf("failed to decode image: %w", err)
+		return 0, "", fmt.Errorf("failed to decode image: %w", err)
 	}
 
 	// Get base filename without extension
-	baseFilename := strings.TrimSuffix(filepath.Base(a.selectedFile), filepath.Ext(a.selectedFile))
-	outputDir := filepath.Dir(a.selectedFile)
+	baseFilename := strings.TrimSuffix(filepath.Base(selectedFile), filepath.Ext(selectedFile))
+	outputDir := filepath.Dir(selectedFile)
 
 	// Create output directory for the bundle
 	bundleDir := filepath.Join(outputDir, baseFilename+"_emote_bundle")
 	err = os.MkdirAll(bundleDir, 0755)
 	if err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+		return 0, "", fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Convert to all sizes
-	for _, size := range emoteSizes {
-		// Resize image maintaining aspect ratio, then crop to exact size
-		resized := imaging.Fill(img, size.Width, size.Height, imaging.Center, imaging.Lanczos)
-		
-		// Create filename
-		filename := fmt.Sprintf("%s-%s-%s-%dx%d.png", 
-			baseFilename, size.Platform, size.Name, size.Width, size.Height)
+	jobs := make([]thumbnailer.Job, 0, len(sizes))
+	for _, size := range sizes {
+		size := size
+
+		format := size.OutputFormat
+		if format == "" {
+			format = FormatPNG
+		}
+		if !size.Animated && a.outputFormat != "" {
+			// Scope note: animated WebP/AVIF output is not implemented.
+			// Neither github.com/chai2010/webp nor github.com/gen2brain/avif
+			// expose an animation/muxing API here, only single-frame
+			// encode, so the bundle-wide format dropdown (labeled
+			// "static sizes only" in the UI) is restricted to static
+			// sizes; animated targets keep encoding as GIF regardless of
+			// this dropdown (via resizeAnimatedGIF when the source is a
+			// GIF, as a single-frame GIF otherwise).
+			format = a.outputFormat
+		}
+
+		strategy := size.Strategy
+		if strategy == "" {
+			strategy = a.strategy
+		}
+		filter := a.resampleFilter
+
+		filename := fmt.Sprintf("%s-%s-%s-%dx%d.%s",
+			baseFilename, size.Platform, size.Name, size.Width, size.Height, format)
 		outputPath := filepath.Join(bundleDir, filename)
 
-		// Save as PNG to preserve transparency
-		err = imaging.Save(resized, outputPath)
+		jobs = append(jobs, thumbnailer.Job{
+			Name: fmt.Sprintf("%s: %s", baseFilename, filename),
+			Run: func() error {
+				if size.Animated && isGIF {
+					return resizeAnimatedGIF(anim, size, strategy, filter, outputPath)
+				}
+				src := img
+				if src == nil {
+					// Static target but the source was an (animated or
+					// single-frame) GIF: fall back to its first frame.
+					src = anim.Image[0]
+				}
+				resized := resizeImage(src, size.Width, size.Height, strategy, filter)
+				return encodeToFile(resized, format, outputPath, size.MaxBytes)
+			},
+		})
+	}
+
+	pool := thumbnailer.NewPool(runtime.NumCPU())
+	failures := 0
+	for event := range pool.Run(jobs) {
+		a.progressBar.SetValue(float64(event.Completed) / float64(event.Total))
+
+		line := event.Filename
+		if event.Err != nil {
+			failures++
+			line = fmt.Sprintf("%s: failed (%s)", event.Filename, event.Err)
+		}
+		a.statusItems.Append(line)
+	}
+
+	return failures, bundleDir, nil
+}
+
+// zipBundles packages every bundle directory into a single zip at
+// zipPath, nesting each bundle's files under its own directory name so
+// multiple converted images don't collide.
+func zipBundles(bundleDirs []string, zipPath string) error {
+	zipFile, err := os.Create(zipPath)
+	if err != nil {
+		return err
+	}
+	defer zipFile.Close()
+
+	zw := zip.NewWriter(zipFile)
+
+	for _, dir := range bundleDirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			src, err := os.Open(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return err
+			}
+
+			w, err := zw.Create(filepath.Join(filepath.Base(dir), entry.Name()))
+			if err != nil {
+				src.Close()
+				return err
+			}
+			if _, err := io.Copy(w, src); err != nil {
+				src.Close()
+				return err
+			}
+			src.Close()
+		}
+	}
+
+	return zw.Close()
+}
+
+// encodeToFile encodes img in the given format and writes it to
+// outputPath. When maxBytes is non-zero and format supports a quality
+// knob (WebP, AVIF), it binary-searches quality in
+// [minQuality, maxQuality] for the highest quality that still fits the
+// budget, returning an error if even minQuality doesn't fit.
+func encodeToFile(img image.Image, format OutputFormat, outputPath string, maxBytes int) error {
+	data, err := encodeToBytes(img, format, maxBytes)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, data, 0644)
+}
+
+func encodeToBytes(img image.Image, format OutputFormat, maxBytes int) ([]byte, error) {
+	switch format {
+	case FormatPNG:
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatGIF:
+		var buf bytes.Buffer
+		if err := gif.Encode(&buf, img, &gif.Options{NumColors: 256}); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatWebP:
+		return encodeWithBudget(img, maxBytes, func(quality float32) ([]byte, error) {
+			var buf bytes.Buffer
+			if err := webp.Encode(&buf, img, &webp.Options{Quality: quality}); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		})
+	case FormatAVIF:
+		return encodeWithBudget(img, maxBytes, func(quality float32) ([]byte, error) {
+			var buf bytes.Buffer
+			if err := avif.Encode(&buf, img, avif.Options{Quality: int(quality)}); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		})
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// encodeWithBudget binary-searches the quality parameter of encodeAt
+// between minQuality and maxQuality for the highest quality whose
+// encoded size is still within maxBytes. With maxBytes == 0 it simply
+// encodes once at maxQuality.
+func encodeWithBudget(img image.Image, maxBytes int, encodeAt func(quality float32) ([]byte, error)) ([]byte, error) {
+	if maxBytes == 0 {
+		return encodeAt(maxQuality)
+	}
+
+	best, err := encodeAt(minQuality)
+	if err != nil {
+		return nil, err
+	}
+	if len(best) > maxBytes {
+		return nil, fmt.Errorf("cannot fit under %d bytes even at lowest quality (%d)", maxBytes, minQuality)
+	}
+
+	lo, hi := minQuality, maxQuality
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		data, err := encodeAt(float32(mid))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) <= maxBytes {
+			best = data
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	return best, nil
+}
+
+// compositeGIFFrames renders each frame of src onto the full logical
+// screen (src.Config.Width/Height), respecting the previous frame's
+// Disposal method, and returns one fully-composited RGBA image per
+// frame. gif.DecodeAll frames are often sub-rectangle delta patches
+// (the partial-frame optimization used by gifsicle, ezgif, etc.), so
+// resizing a raw frame directly would stretch that small patch across
+// the whole output instead of the complete picture it represents.
+func compositeGIFFrames(src *gif.GIF) []*image.NRGBA {
+	w, h := src.Config.Width, src.Config.Height
+	canvas := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	frames := make([]*image.NRGBA, len(src.Image))
+	var previousRect image.Rectangle
+	var previousDisposal byte
+	var previousCanvas *image.NRGBA // canvas state right before the previous frame was drawn
+
+	for i, frame := range src.Image {
+		switch previousDisposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, previousRect, image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			if previousCanvas != nil {
+				draw.Draw(canvas, previousRect, previousCanvas, previousRect.Min, draw.Src)
+			}
+		}
+
+		// Snapshot unconditionally: if this frame's own disposal turns
+		// out to be DisposalPrevious, restoring after it must use the
+		// canvas exactly as it stood right before this frame was
+		// drawn, not some earlier frame's snapshot.
+		beforeFrame := imaging.Clone(canvas)
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		frames[i] = imaging.Clone(canvas)
+
+		previousRect = frame.Bounds()
+		previousCanvas = beforeFrame
+		if i < len(src.Disposal) {
+			previousDisposal = src.Disposal[i]
+		} else {
+			previousDisposal = gif.DisposalNone
+		}
+	}
+
+	return frames
+}
+
+// resizeAnimatedGIF resizes every frame of src to size's dimensions
+// using the given strategy and filter, preserving timing and loop
+// count, and writes the result to outputPath as an animated GIF. Each
+// frame is first composited onto the full logical screen so
+// sub-rectangle delta frames are resized as complete pictures rather
+// than as their raw (possibly tiny) patch.
+func resizeAnimatedGIF(src *gif.GIF, size EmoteSize, strategy ResizeStrategy, filter ResampleFilter, outputPath string) error {
+	composited := compositeGIFFrames(src)
+
+	// For SmartCrop, compute the crop window once from the first frame
+	// and reuse it for every frame, rather than letting each frame's
+	// own Sobel energy map pick an independent window — otherwise
+	// per-frame compression noise or subject motion shifts the crop
+	// frame to frame, producing a jittery emote.
+	var cropRect image.Rectangle
+	if strategy == StrategySmartCrop && len(composited) > 0 {
+		cropRect = smartCropRect(composited[0], size.Width, size.Height)
+	}
+
+	resized := make([]image.Image, len(composited))
+	for i, frame := range composited {
+		resized[i] = resizeImageCropped(frame, size.Width, size.Height, strategy, filter, cropRect)
+	}
+
+	return encodeAnimatedGIF(resized, src.Delay, src.LoopCount, outputPath, size.MaxBytes)
+}
+
+// gifColorSteps are the palette sizes encodeAnimatedGIF tries, largest
+// first, when shrinking an animated GIF to fit a byte budget.
+var gifColorSteps = []int{256, 128, 64, 32, 16, 8}
+
+// encodeAnimatedGIF quantizes frames and writes them to outputPath as
+// an animated GIF with the given per-frame delay and loop count. When
+// maxBytes is non-zero, it progressively reduces palette size and
+// then drops frames (folding their delay into the frame kept before
+// them) until the encoded GIF fits the budget, mirroring
+// encodeWithBudget's behavior for the static formats. It errors out
+// if nothing fits even at the lowest color depth and frame rate.
+func encodeAnimatedGIF(frames []image.Image, delay []int, loopCount int, outputPath string, maxBytes int) error {
+	encodeAt := func(numColors, frameStride int) ([]byte, error) {
+		usedFrames, usedDelay := subsampleFrames(frames, delay, frameStride)
+		out := &gif.GIF{
+			LoopCount: loopCount,
+			Delay:     usedDelay,
+			Disposal:  make([]byte, len(usedFrames)),
+			Image:     make([]*image.Paletted, len(usedFrames)),
+		}
+		for i, f := range usedFrames {
+			out.Image[i] = quantizeFrameN(f, numColors)
+			out.Disposal[i] = gif.DisposalNone
+		}
+		var buf bytes.Buffer
+		if err := gif.EncodeAll(&buf, out); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var data []byte
+	var err error
+	if maxBytes == 0 {
+		data, err = encodeAt(256, 1)
 		if err != nil {
-			return fmt.Errorf("failed to save %s: %w", filename, err)
+			return err
+		}
+	} else {
+		data = nil
+		for _, stride := range []int{1, 2, 3, 4} {
+			for _, numColors := range gifColorSteps {
+				data, err = encodeAt(numColors, stride)
+				if err != nil {
+					return err
+				}
+				if len(data) <= maxBytes {
+					break
+				}
+				data = nil
+			}
+			if data != nil {
+				break
+			}
+		}
+		if data == nil {
+			return fmt.Errorf("cannot fit animated GIF under %d bytes even at lowest color depth and frame rate", maxBytes)
+		}
+	}
+
+	return os.WriteFile(outputPath, data, 0644)
+}
+
+// subsampleFrames keeps every stride-th frame, folding each dropped
+// frame's delay into the kept frame before it so the overall
+// animation duration is preserved. stride <= 1 returns frames and
+// delay unchanged.
+func subsampleFrames(frames []image.Image, delay []int, stride int) ([]image.Image, []int) {
+	if stride <= 1 {
+		return frames, delay
+	}
+
+	var kept []image.Image
+	var keptDelay []int
+	for i := 0; i < len(frames); i += stride {
+		d := delay[i]
+		for j := i + 1; j < i+stride && j < len(frames); j++ {
+			d += delay[j]
+		}
+		kept = append(kept, frames[i])
+		keptDelay = append(keptDelay, d)
+	}
+	return kept, keptDelay
+}
+
+// quantizeFrame converts an arbitrary image down to a paletted image
+// with at most 256 colors, dithering with Floyd-Steinberg and keeping
+// fully-transparent pixels mapped to a dedicated transparent palette
+// entry so downstream GIF disposal/transparency behaves correctly.
+func quantizeFrame(img image.Image) *image.Paletted {
+	return quantizeFrameN(img, 256)
+}
+
+// quantizeFrameN is quantizeFrame with an explicit palette size, so
+// encodeAnimatedGIF can trade color depth for file size.
+func quantizeFrameN(img image.Image, numColors int) *image.Paletted {
+	if numColors > len(palette.Plan9) {
+		numColors = len(palette.Plan9)
+	}
+	bounds := img.Bounds()
+	pal := make(color.Palette, numColors)
+	copy(pal, palette.Plan9[:numColors])
+	pal[0] = color.Transparent
+
+	paletted := image.NewPaletted(bounds, pal)
+	draw.FloydSteinberg.Draw(paletted, bounds, img, bounds.Min)
+
+	// Re-map any pixel that was (or rounds to) fully transparent onto
+	// the transparent palette entry, since Floyd-Steinberg dithering
+	// can otherwise bleed partially-transparent edge pixels onto an
+	// opaque palette color.
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			_, _, _, alpha := img.At(x, y).RGBA()
+			if alpha == 0 {
+				paletted.SetColorIndex(x, y, 0)
+			}
+		}
+	}
+
+	return paletted
+}
+
+// resizeImage fits src into width x height according to strategy,
+// using filter for interpolation.
+func resizeImage(src image.Image, width, height int, strategy ResizeStrategy, filter ResampleFilter) image.Image {
+	return resizeImageCropped(src, width, height, strategy, filter, image.Rectangle{})
+}
+
+// resizeImageCropped is resizeImage with an optional precomputed
+// SmartCrop window. Passing a zero Rectangle makes it compute its own,
+// as resizeImage does; callers that need the same crop window reused
+// across multiple frames (an animated GIF) compute it once up front
+// and pass it in here instead, so the crop doesn't jitter frame to
+// frame.
+func resizeImageCropped(src image.Image, width, height int, strategy ResizeStrategy, filter ResampleFilter, cropRect image.Rectangle) image.Image {
+	f := filter.imaging()
+
+	switch strategy {
+	case StrategyFit:
+		return imaging.Fit(src, width, height, f)
+	case StrategyPadTransparent:
+		fitted := imaging.Fit(src, width, height, f)
+		canvas := imaging.New(width, height, color.Transparent)
+		return imaging.PasteCenter(canvas, fitted)
+	case StrategySmartCrop:
+		rect := cropRect
+		if rect.Empty() {
+			rect = smartCropRect(src, width, height)
 		}
+		cropped := imaging.Crop(src, rect)
+		return imaging.Fill(cropped, width, height, imaging.Center, f)
+	default: // StrategyFill
+		return imaging.Fill(src, width, height, imaging.Center, f)
+	}
+}
+
+// smartCropRect picks the targetW:targetH window of src with the
+// highest Sobel edge-density energy, so a subsequent Fill is less
+// likely to decapitate the subject than a plain center-crop.
+func smartCropRect(src image.Image, targetW, targetH int) image.Rectangle {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if srcW <= 0 || srcH <= 0 || targetW <= 0 || targetH <= 0 {
+		return bounds
+	}
+
+	aspect := float64(targetW) / float64(targetH)
+	winW, winH := srcW, int(float64(srcW)/aspect)
+	if winH > srcH {
+		winH = srcH
+		winW = int(float64(srcH) * aspect)
+	}
+	if winW < 1 {
+		winW = 1
+	}
+	if winH < 1 {
+		winH = 1
+	}
+	if winW >= srcW && winH >= srcH {
+		return bounds
 	}
 
-	return nil
+	integral := sobelIntegral(src, srcW, srcH)
+
+	const stride = 4 // scan stride to keep this cheap on large sources
+	bestX, bestY, bestEnergy := 0, 0, -1.0
+	for y := 0; y+winH <= srcH; y += stride {
+		for x := 0; x+winW <= srcW; x += stride {
+			e := windowSum(integral, srcW, x, y, winW, winH)
+			if e > bestEnergy {
+				bestEnergy, bestX, bestY = e, x, y
+			}
+		}
+	}
+
+	return image.Rect(
+		bounds.Min.X+bestX, bounds.Min.Y+bestY,
+		bounds.Min.X+bestX+winW, bounds.Min.Y+bestY+winH,
+	)
+}
+
+// sobelIntegral returns the (w+1)x(h+1) summed-area table of the Sobel
+// gradient magnitude at every pixel, so windowSum can answer a
+// rectangle's total energy in O(1).
+func sobelIntegral(src image.Image, w, h int) []float64 {
+	gray := make([]float64, w*h)
+	bounds := src.Bounds()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, b, _ := src.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			gray[y*w+x] = 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+		}
+	}
+
+	at := func(x, y int) float64 {
+		if x < 0 {
+			x = 0
+		} else if x >= w {
+			x = w - 1
+		}
+		if y < 0 {
+			y = 0
+		} else if y >= h {
+			y = h - 1
+		}
+		return gray[y*w+x]
+	}
+
+	integral := make([]float64, (w+1)*(h+1))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			gx := (at(x+1, y-1) + 2*at(x+1, y) + at(x+1, y+1)) -
+				(at(x-1, y-1) + 2*at(x-1, y) + at(x-1, y+1))
+			gy := (at(x-1, y+1) + 2*at(x, y+1) + at(x+1, y+1)) -
+				(at(x-1, y-1) + 2*at(x, y-1) + at(x+1, y-1))
+			magnitude := math.Sqrt(gx*gx + gy*gy)
+
+			row := (y + 1) * (w + 1)
+			prevRow := y * (w + 1)
+			integral[row+x+1] = magnitude + integral[row+x] + integral[prevRow+x+1] - integral[prevRow+x]
+		}
+	}
+
+	return integral
+}
+
+// windowSum returns the sum of a w-wide summed-area table's values over
+// the rectangle [x, x+ww) x [y, y+wh).
+func windowSum(integral []float64, w, x, y, ww, wh int) float64 {
+	stride := w + 1
+	a := integral[y*stride+x]
+	b := integral[y*stride+x+ww]
+	c := integral[(y+wh)*stride+x]
+	d := integral[(y+wh)*stride+x+ww]
+	return d - b - c + a
 }
 
 func (a *App) loadPreview() {
@@ -238,13 +1150,13 @@ func (a *App) loadPreview() {
 
 	// Set the image and show preview
 	a.previewImage.SetResource(resource)
-	
+
 	// Set the preview image size to 256x256
 	a.previewImage.Resize(fyne.NewSize(256, 256))
-	
+
 	a.previewCard.Show()
 	a.previewCard.Refresh()
-	
+
 	// Resize window to accommodate larger preview
 	// a.window.Resize(fyne.NewSize(600, 550))
 }
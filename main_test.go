@@ -0,0 +1,185 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+// solidPaletted returns a w x h paletted frame where every pixel is
+// transparent except pos, which is set to idx.
+func solidPaletted(w, h int, pal color.Palette, pos image.Point, idx uint8) *image.Paletted {
+	frame := image.NewPaletted(image.Rect(0, 0, w, h), pal)
+	frame.SetColorIndex(pos.X, pos.Y, idx)
+	return frame
+}
+
+// TestCompositeGIFFramesDisposalPrevious reproduces the scenario from
+// the chunk0-1 review: frames 0-4 paint a pixel red under
+// DisposalNone, then frame 5 overwrites that pixel under
+// DisposalPrevious. Frame 6 must see the pixel restored to red, the
+// state immediately before frame 5 was drawn, not some earlier
+// snapshot.
+func TestCompositeGIFFramesDisposalPrevious(t *testing.T) {
+	pal := color.Palette{color.Transparent, color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}}
+	pos := image.Point{X: 1, Y: 1}
+
+	src := &gif.GIF{
+		Config: image.Config{Width: 3, Height: 3},
+	}
+	for i := 0; i < 5; i++ {
+		src.Image = append(src.Image, solidPaletted(3, 3, pal, pos, 1)) // red
+		src.Disposal = append(src.Disposal, gif.DisposalNone)
+	}
+	// Frame 5: overwrite the pixel blue, then restore on disposal.
+	src.Image = append(src.Image, solidPaletted(3, 3, pal, pos, 2)) // blue
+	src.Disposal = append(src.Disposal, gif.DisposalPrevious)
+	// Frame 6: touches nothing; should show the restored pixel.
+	src.Image = append(src.Image, solidPaletted(3, 3, pal, pos, 0)) // transparent
+	src.Disposal = append(src.Disposal, gif.DisposalNone)
+
+	frames := compositeGIFFrames(src)
+	if len(frames) != len(src.Image) {
+		t.Fatalf("got %d composited frames, want %d", len(frames), len(src.Image))
+	}
+
+	got := frames[6].NRGBAAt(pos.X, pos.Y)
+	want := color.NRGBAModel.Convert(pal[1]).(color.NRGBA)
+	if got != want {
+		t.Errorf("frame 6 pixel %v = %v, want %v (red, restored from before frame 5)", pos, got, want)
+	}
+}
+
+// TestCompositeGIFFramesDisposalBackground checks that
+// DisposalBackground clears the previous frame's rectangle to
+// transparent rather than leaving it untouched.
+func TestCompositeGIFFramesDisposalBackground(t *testing.T) {
+	pal := color.Palette{color.Transparent, color.RGBA{R: 255, A: 255}}
+	pos := image.Point{X: 0, Y: 0}
+
+	src := &gif.GIF{
+		Config:   image.Config{Width: 2, Height: 2},
+		Image:    []*image.Paletted{solidPaletted(2, 2, pal, pos, 1), solidPaletted(2, 2, pal, pos, 0)},
+		Disposal: []byte{gif.DisposalBackground, gif.DisposalNone},
+	}
+
+	frames := compositeGIFFrames(src)
+	got := frames[1].NRGBAAt(pos.X, pos.Y)
+	if got != (color.NRGBA{}) {
+		t.Errorf("frame 1 pixel %v = %v, want fully transparent after DisposalBackground", pos, got)
+	}
+}
+
+func TestSubsampleFrames(t *testing.T) {
+	frames := []image.Image{
+		image.NewNRGBA(image.Rect(0, 0, 1, 1)),
+		image.NewNRGBA(image.Rect(0, 0, 1, 1)),
+		image.NewNRGBA(image.Rect(0, 0, 1, 1)),
+		image.NewNRGBA(image.Rect(0, 0, 1, 1)),
+		image.NewNRGBA(image.Rect(0, 0, 1, 1)),
+	}
+	delay := []int{10, 10, 10, 10, 10}
+
+	tests := []struct {
+		name       string
+		stride     int
+		wantFrames int
+		wantDelay  []int
+	}{
+		{"stride 1 is a no-op", 1, 5, []int{10, 10, 10, 10, 10}},
+		{"stride 2 folds delays", 2, 3, []int{20, 20, 10}},
+		{"stride 3 folds delays", 3, 2, []int{30, 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, keptDelay := subsampleFrames(frames, delay, tt.stride)
+			if len(kept) != tt.wantFrames {
+				t.Fatalf("got %d frames, want %d", len(kept), tt.wantFrames)
+			}
+			if len(keptDelay) != len(tt.wantDelay) {
+				t.Fatalf("got %d delays, want %d", len(keptDelay), len(tt.wantDelay))
+			}
+			for i, d := range keptDelay {
+				if d != tt.wantDelay[i] {
+					t.Errorf("delay[%d] = %d, want %d", i, d, tt.wantDelay[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSmartCropRect(t *testing.T) {
+	t.Run("degenerate dimensions return the full bounds", func(t *testing.T) {
+		src := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+		got := smartCropRect(src, 0, 10)
+		if got != src.Bounds() {
+			t.Errorf("got %v, want %v", got, src.Bounds())
+		}
+	})
+
+	t.Run("window no smaller than source returns the full bounds", func(t *testing.T) {
+		src := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+		got := smartCropRect(src, 10, 10)
+		if got != src.Bounds() {
+			t.Errorf("got %v, want %v", got, src.Bounds())
+		}
+	})
+
+	t.Run("picks the window containing the only edge", func(t *testing.T) {
+		src := image.NewNRGBA(image.Rect(0, 0, 20, 10))
+		// Paint a bright square near the right edge; everything else
+		// stays black, so that's where all the Sobel energy is.
+		squareRect := image.Rect(14, 2, 18, 8)
+		for y := squareRect.Min.Y; y < squareRect.Max.Y; y++ {
+			for x := squareRect.Min.X; x < squareRect.Max.X; x++ {
+				src.Set(x, y, color.White)
+			}
+		}
+
+		rect := smartCropRect(src, 10, 10)
+		if rect.Intersect(squareRect).Empty() {
+			t.Errorf("smartCropRect picked %v, expected it to overlap the high-energy square %v", rect, squareRect)
+		}
+	})
+}
+
+func TestEncodeWithBudget(t *testing.T) {
+	// Simulated encoder: higher quality produces a larger payload.
+	encodeAt := func(quality float32) ([]byte, error) {
+		return make([]byte, int(quality)*10), nil
+	}
+
+	t.Run("no budget encodes once at max quality", func(t *testing.T) {
+		data, err := encodeWithBudget(nil, 0, encodeAt)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(data) != maxQuality*10 {
+			t.Errorf("got %d bytes, want %d", len(data), maxQuality*10)
+		}
+	})
+
+	t.Run("binary searches for the highest quality under budget", func(t *testing.T) {
+		data, err := encodeWithBudget(nil, 500, encodeAt)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(data) > 500 {
+			t.Errorf("got %d bytes, exceeds budget of 500", len(data))
+		}
+		// Quality 50 -> 500 bytes is the best fit the simulated
+		// encoder can produce without exceeding the budget.
+		if len(data) != 500 {
+			t.Errorf("got %d bytes, want the tightest fit of 500", len(data))
+		}
+	})
+
+	t.Run("errors when even the lowest quality doesn't fit", func(t *testing.T) {
+		_, err := encodeWithBudget(nil, 1, encodeAt)
+		if err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+	})
+}
@@ -0,0 +1,86 @@
+// Package thumbnailer provides a small worker-pool for fanning a batch
+// of image-encode jobs across multiple goroutines, in the spirit of a
+// queue-driven thumbnail worker: a bounded pool of workers pulls jobs
+// off a channel and reports progress as each one finishes.
+package thumbnailer
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Job is a single unit of work for the pool: a human-readable name for
+// progress reporting and the function that performs the actual resize
+// and encode.
+type Job struct {
+	Name string
+	Run  func() error
+}
+
+// ProgressEvent reports the outcome of one completed job.
+type ProgressEvent struct {
+	Completed int
+	Total     int
+	Filename  string
+	Err       error
+}
+
+// Pool fans jobs across a fixed number of worker goroutines.
+type Pool struct {
+	workers int
+}
+
+// NewPool creates a Pool with the given number of workers. workers is
+// clamped to at least 1.
+func NewPool(workers int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Pool{workers: workers}
+}
+
+// Run submits jobs to the pool and returns a channel of ProgressEvent,
+// one per completed job, in completion order. The channel is closed
+// once every job has been processed. A job returning an error does not
+// stop the remaining jobs from running.
+func (p *Pool) Run(jobs []Job) <-chan ProgressEvent {
+	total := len(jobs)
+	jobCh := make(chan Job, total)
+	for _, j := range jobs {
+		jobCh <- j
+	}
+	close(jobCh)
+
+	events := make(chan ProgressEvent, total)
+
+	workers := p.workers
+	if total > 0 && workers > total {
+		workers = total
+	}
+
+	var completed int32
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				err := job.Run()
+				n := atomic.AddInt32(&completed, 1)
+				events <- ProgressEvent{
+					Completed: int(n),
+					Total:     total,
+					Filename:  job.Name,
+					Err:       err,
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events
+}